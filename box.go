@@ -10,14 +10,17 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"math"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
-	"unicode/utf8"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
 )
@@ -105,6 +108,88 @@ type ColorTheme struct {
 	startColor int
 	usedColors map[int]bool
 	colorIndex int
+
+	// gradientFrom/gradientTo are the RGB endpoints for a truecolor gradient
+	// theme (name == "rgbgradient"), interpolated across gradientSteps total
+	// layers or lines.
+	gradientFrom  [3]int
+	gradientTo    [3]int
+	gradientSteps int
+}
+
+// newRGBGradientTheme creates a color theme that interpolates a smooth
+// 24-bit RGB gradient between from and to across steps total nested layers
+// or content lines.
+func newRGBGradientTheme(from, to [3]int, steps int) *ColorTheme {
+	if steps < 1 {
+		steps = 1
+	}
+	return &ColorTheme{
+		name:          "rgbgradient",
+		gradientFrom:  from,
+		gradientTo:    to,
+		gradientSteps: steps,
+	}
+}
+
+// sRGBToLinear converts a single 0-255 sRGB channel value to linear light,
+// via the standard piecewise transform.
+func sRGBToLinear(c int) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB is the inverse of sRGBToLinear, returning a clamped 0-255
+// sRGB channel value.
+func linearToSRGB(v float64) int {
+	var s float64
+	if v <= 0.0031308 {
+		s = v * 12.92
+	} else {
+		s = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	c := int(s*255 + 0.5)
+	if c < 0 {
+		return 0
+	}
+	if c > 255 {
+		return 255
+	}
+	return c
+}
+
+// lerpRGBLinear interpolates between two sRGB colors in linear RGB space
+// (rather than sRGB space, for perceptually smoother gradients) at t in
+// [0, 1].
+func lerpRGBLinear(from, to [3]int, t float64) [3]int {
+	var out [3]int
+	for i := 0; i < 3; i++ {
+		a := sRGBToLinear(from[i])
+		b := sRGBToLinear(to[i])
+		out[i] = linearToSRGB(a + (b-a)*t)
+	}
+	return out
+}
+
+// parseGradientSpec parses a "FROM:TO" --gradient flag value, where each
+// endpoint is a "#rrggbb" truecolor, into its two RGB endpoints.
+func parseGradientSpec(spec string) (from, to [3]int, err error) {
+	parts := strings.SplitN(spec, ":", 2)
+	if len(parts) != 2 {
+		return from, to, fmt.Errorf("gradient spec must be FROM:TO, got %q", spec)
+	}
+	fr, fg, fb, ok := parseHexColor(parts[0])
+	if !ok {
+		return from, to, fmt.Errorf("invalid gradient endpoint %q", parts[0])
+	}
+	tr, tg, tb, ok := parseHexColor(parts[1])
+	if !ok {
+		return from, to, fmt.Errorf("invalid gradient endpoint %q", parts[1])
+	}
+	return [3]int{fr, fg, fb}, [3]int{tr, tg, tb}, nil
 }
 
 // getNextColor returns the next color based on the theme and index, avoiding repetition
@@ -195,11 +280,140 @@ func newColorTheme(name string) *ColorTheme {
 
 // getColorFromTheme returns a color.Color based on the theme and index
 func getColorFromTheme(theme *ColorTheme, index int) *color.Color {
+	if theme.name == "rgbgradient" {
+		t := 0.0
+		if theme.gradientSteps > 1 {
+			t = float64(index) / float64(theme.gradientSteps-1)
+		}
+		rgb := lerpRGBLinear(theme.gradientFrom, theme.gradientTo, t)
+		// Use 24-bit truecolor sequence: ESC[38;2;R;G;Bm for foreground
+		return color.New(color.Attribute(38), color.Attribute(2), color.Attribute(rgb[0]), color.Attribute(rgb[1]), color.Attribute(rgb[2]))
+	}
 	colorCode := getNextColor(theme, index)
 	// Use ANSI 256-color sequence: ESC[38;5;<n>m for foreground
 	return color.New(color.Attribute(38), color.Attribute(5), color.Attribute(colorCode))
 }
 
+// parseLSColors parses the LS_COLORS environment variable (the
+// key=attr;attr:... format used by GNU coreutils dircolors) into a map from
+// file-type key (e.g. "di", "ln") or "*.ext" glob pattern to its raw SGR
+// attribute string (e.g. "01;34"). An empty or missing value yields an
+// empty map.
+func parseLSColors(env string) map[string]string {
+	m := make(map[string]string)
+	for _, entry := range strings.Split(env, ":") {
+		if entry == "" {
+			continue
+		}
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			continue
+		}
+		m[kv[0]] = kv[1]
+	}
+	return m
+}
+
+// lsColorKeyForPath determines which LS_COLORS file-type key applies to
+// path, based on os.Lstat mode bits. It reports ok=false when path does not
+// exist on disk at all, so prose that merely resembles text isn't mis-tagged
+// as a missing file; "mi"/"or" are only reachable via a dangling symlink.
+func lsColorKeyForPath(path string, lsColors map[string]string) (string, bool) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return "", false
+	}
+	mode := info.Mode()
+	switch {
+	case mode&os.ModeSymlink != 0:
+		if _, err := os.Stat(path); err != nil {
+			if _, ok := lsColors["or"]; ok {
+				return "or", true
+			}
+			if _, ok := lsColors["mi"]; ok {
+				return "mi", true
+			}
+		}
+		return "ln", true
+	case mode.IsDir():
+		switch {
+		case mode&os.ModeSticky != 0 && mode&0002 != 0:
+			return "tw", true
+		case mode&0002 != 0:
+			return "ow", true
+		case mode&os.ModeSticky != 0:
+			return "st", true
+		default:
+			return "di", true
+		}
+	case mode&os.ModeNamedPipe != 0:
+		return "pi", true
+	case mode&os.ModeSocket != 0:
+		return "so", true
+	case mode&os.ModeDevice != 0:
+		if mode&os.ModeCharDevice != 0 {
+			return "cd", true
+		}
+		return "bd", true
+	case mode&os.ModeSetuid != 0:
+		return "su", true
+	case mode&os.ModeSetgid != 0:
+		return "sg", true
+	case mode&0111 != 0:
+		return "ex", true
+	default:
+		return "fi", true
+	}
+}
+
+// lsColorStyleForPath resolves the SGR attribute string for path, preferring
+// a "*.ext" glob match over the general file-type key, and falling back to
+// "fi" when the specific key has no entry. It reports ok=false for blank
+// lines and lines that don't look like a path on disk, so prose content
+// passes through uncolored.
+func lsColorStyleForPath(path string, lsColors map[string]string) (string, bool) {
+	if path == "" {
+		return "", false
+	}
+	key, ok := lsColorKeyForPath(path, lsColors)
+	if !ok {
+		return "", false
+	}
+	if key == "fi" || key == "ex" {
+		if ext := filepath.Ext(path); ext != "" {
+			if style, ok := lsColors["*"+ext]; ok {
+				return style, true
+			}
+		}
+	}
+	if style, ok := lsColors[key]; ok {
+		return style, true
+	}
+	if key != "fi" {
+		if style, ok := lsColors["fi"]; ok {
+			return style, true
+		}
+	}
+	return "", false
+}
+
+// colorizeLSColors styles each line that resolves to an on-disk path via
+// os.Lstat according to lsColors, so piping `ls -1` or `find` through box
+// produces a correctly-tinted listing. Lines that don't look like a path
+// (including blank separator lines used by --join) pass through unchanged.
+func colorizeLSColors(lines []string, lsColors map[string]string) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		style, ok := lsColorStyleForPath(line, lsColors)
+		if !ok {
+			out[i] = line
+			continue
+		}
+		out[i] = fmt.Sprintf("\x1b[%sm%s\x1b[0m", style, line)
+	}
+	return out
+}
+
 func getTheme(name string) Theme {
 	switch name {
 	case "unicode":
@@ -213,48 +427,148 @@ func getTheme(name string) Theme {
 	}
 }
 
-// parseColor parses a color name into a color.Color
+// fgColorAttrs maps named colors to their foreground SGR attribute, for the
+// plain-color segment of a parseColor spec.
+var fgColorAttrs = map[string]color.Attribute{
+	"black":          color.FgBlack,
+	"red":            color.FgRed,
+	"green":          color.FgGreen,
+	"yellow":         color.FgYellow,
+	"blue":           color.FgBlue,
+	"magenta":        color.FgMagenta,
+	"cyan":           color.FgCyan,
+	"white":          color.FgWhite,
+	"gray":           color.FgHiBlack,
+	"bright_black":   color.FgHiBlack,
+	"bright_red":     color.FgHiRed,
+	"bright_green":   color.FgHiGreen,
+	"bright_yellow":  color.FgHiYellow,
+	"bright_blue":    color.FgHiBlue,
+	"bright_magenta": color.FgHiMagenta,
+	"bright_cyan":    color.FgHiCyan,
+	"bright_white":   color.FgHiWhite,
+}
+
+// bgColorAttrs maps named colors to their background SGR attribute, for the
+// "bg=..." segment of a parseColor spec.
+var bgColorAttrs = map[string]color.Attribute{
+	"black":          color.BgBlack,
+	"red":            color.BgRed,
+	"green":          color.BgGreen,
+	"yellow":         color.BgYellow,
+	"blue":           color.BgBlue,
+	"magenta":        color.BgMagenta,
+	"cyan":           color.BgCyan,
+	"white":          color.BgWhite,
+	"gray":           color.BgHiBlack,
+	"bright_black":   color.BgHiBlack,
+	"bright_red":     color.BgHiRed,
+	"bright_green":   color.BgHiGreen,
+	"bright_yellow":  color.BgHiYellow,
+	"bright_blue":    color.BgHiBlue,
+	"bright_magenta": color.BgHiMagenta,
+	"bright_cyan":    color.BgHiCyan,
+	"bright_white":   color.BgHiWhite,
+}
+
+// styleAttrs maps text-style keywords to their SGR attribute, for the
+// style segments of a parseColor spec (e.g. "bold", "underline").
+var styleAttrs = map[string]color.Attribute{
+	"bold":          color.Bold,
+	"dim":           color.Faint,
+	"italic":        color.Italic,
+	"underline":     color.Underline,
+	"reverse":       color.ReverseVideo,
+	"blink":         color.BlinkSlow,
+	"strikethrough": color.CrossedOut,
+}
+
+// parseHexColor parses a "#rrggbb" truecolor spec into its R, G, B components.
+func parseHexColor(value string) (r, g, b int, ok bool) {
+	if !strings.HasPrefix(value, "#") || len(value) != 7 {
+		return 0, 0, 0, false
+	}
+	n, err := strconv.ParseInt(value[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(n >> 16 & 0xff), int(n >> 8 & 0xff), int(n & 0xff), true
+}
+
+// applyForeground adds the foreground attribute(s) for value (a named color,
+// a 256-color number, or a "#rrggbb" truecolor) to c. It reports whether
+// value was recognized.
+func applyForeground(c *color.Color, value string) bool {
+	if attr, ok := fgColorAttrs[strings.ToLower(value)]; ok {
+		c.Add(attr)
+		return true
+	}
+	if r, g, b, ok := parseHexColor(value); ok {
+		// 24-bit foreground: ESC[38;2;R;G;Bm
+		c.Add(color.Attribute(38), color.Attribute(2), color.Attribute(r), color.Attribute(g), color.Attribute(b))
+		return true
+	}
+	if num, err := strconv.Atoi(value); err == nil {
+		// 256-color foreground: ESC[38;5;<n>m
+		c.Add(color.Attribute(38), color.Attribute(5), color.Attribute(num))
+		return true
+	}
+	return false
+}
+
+// applyBackground adds the background attribute(s) for value (a named color,
+// a 256-color number, or a "#rrggbb" truecolor) to c. It reports whether
+// value was recognized.
+func applyBackground(c *color.Color, value string) bool {
+	if attr, ok := bgColorAttrs[strings.ToLower(value)]; ok {
+		c.Add(attr)
+		return true
+	}
+	if r, g, b, ok := parseHexColor(value); ok {
+		// 24-bit background: ESC[48;2;R;G;Bm
+		c.Add(color.Attribute(48), color.Attribute(2), color.Attribute(r), color.Attribute(g), color.Attribute(b))
+		return true
+	}
+	if num, err := strconv.Atoi(value); err == nil {
+		// 256-color background: ESC[48;5;<n>m
+		c.Add(color.Attribute(48), color.Attribute(5), color.Attribute(num))
+		return true
+	}
+	return false
+}
+
+// parseColor parses a color spec into a color.Color. A spec is one or more
+// colon-separated segments: a foreground color (named, 256-color number, or
+// "#rrggbb" truecolor), a "bg=..." background color in the same formats, or
+// a style attribute (bold, dim, italic, underline, reverse, blink,
+// strikethrough). For example: "red:bold:underline" or
+// "#ff8800:italic:bg=blue".
 func parseColor(name string) *color.Color {
-	switch strings.ToLower(name) {
-	case "black":
-		return color.New(color.FgBlack)
-	case "red":
-		return color.New(color.FgRed)
-	case "green":
-		return color.New(color.FgGreen)
-	case "yellow":
-		return color.New(color.FgYellow)
-	case "blue":
-		return color.New(color.FgBlue)
-	case "magenta":
-		return color.New(color.FgMagenta)
-	case "cyan":
-		return color.New(color.FgCyan)
-	case "white":
-		return color.New(color.FgWhite)
-	case "gray", "bright_black":
-		return color.New(color.FgHiBlack)
-	case "bright_red":
-		return color.New(color.FgHiRed)
-	case "bright_green":
-		return color.New(color.FgHiGreen)
-	case "bright_yellow":
-		return color.New(color.FgHiYellow)
-	case "bright_blue":
-		return color.New(color.FgHiBlue)
-	case "bright_magenta":
-		return color.New(color.FgHiMagenta)
-	case "bright_cyan":
-		return color.New(color.FgHiCyan)
-	case "bright_white":
-		return color.New(color.FgHiWhite)
-	default:
-		// Try to parse as a number for 256-color mode
-		if num, err := strconv.Atoi(name); err == nil {
-			return color.New(color.FgHiBlack + color.Attribute(num))
+	c := color.New()
+	matched := false
+	for _, seg := range strings.Split(name, ":") {
+		seg = strings.TrimSpace(seg)
+		if seg == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(strings.ToLower(seg), "bg="):
+			if applyBackground(c, seg[len("bg="):]) {
+				matched = true
+			}
+		case styleAttrs[strings.ToLower(seg)] != 0:
+			c.Add(styleAttrs[strings.ToLower(seg)])
+			matched = true
+		default:
+			if applyForeground(c, seg) {
+				matched = true
+			}
 		}
+	}
+	if !matched {
 		return color.New(color.Reset)
 	}
+	return c
 }
 
 func repeatChar(char string, n int) string {
@@ -281,10 +595,23 @@ func stripAnsi(str string) string {
 	return result.String()
 }
 
+// displayWidth returns the terminal column width of s, iterating grapheme
+// clusters (so combining marks and ZWJ emoji sequences count once) and
+// summing each cluster's go-runewidth width. s should already have had
+// stripAnsi applied.
+func displayWidth(s string) int {
+	width := 0
+	gr := uniseg.NewGraphemes(s)
+	for gr.Next() {
+		width += runewidth.StringWidth(gr.Str())
+	}
+	return width
+}
+
 func maxLineWidth(lines []string) int {
 	max := 0
 	for _, line := range lines {
-		l := utf8.RuneCountInString(stripAnsi(line))
+		l := displayWidth(stripAnsi(line))
 		if l > max {
 			max = l
 		}
@@ -303,7 +630,7 @@ func createBox(
 ) []string {
 	maxWidth := maxLineWidth(lines) + 2*hpadding
 	if boxTitle != "" {
-		titleLen := utf8.RuneCountInString(stripAnsi(boxTitle))
+		titleLen := displayWidth(stripAnsi(boxTitle))
 		if titleLen > maxWidth {
 			maxWidth = titleLen
 		}
@@ -322,7 +649,7 @@ func createBox(
 			title = titleColor.Sprint(boxTitle)
 		}
 		top += title
-		rest := maxWidth - utf8.RuneCountInString(stripAnsi(boxTitle))
+		rest := maxWidth - displayWidth(stripAnsi(boxTitle))
 		if boxColor != nil {
 			top += boxColor.Sprint(repeatChar(theme["WE"], rest))
 		} else {
@@ -350,7 +677,7 @@ func createBox(
 		if contentColor != nil {
 			content = contentColor.Sprint(line)
 		}
-		totalPadding := maxWidth - utf8.RuneCountInString(stripped)
+		totalPadding := maxWidth - displayWidth(stripped)
 		boxLines = append(boxLines, fmt.Sprintf("%s%s%s%s%s%s", border(theme["NS"]), padLeft, content, padRight, strings.Repeat(" ", totalPadding-2*hpadding), border(theme["NS"])))
 	}
 	// Vertical padding (bottom)
@@ -378,12 +705,14 @@ func createNestedBoxes(
 	contentColor *color.Color,
 	colorTheme *ColorTheme,
 ) []string {
-	for i := 0; i < depth; i++ { // OUTERMOST is i=0
+	for i := 0; i < depth; i++ { // i=0 wraps the raw content (innermost); i=depth-1 wraps last and ends up outermost
 		var boxColor, titleColor *color.Color
 		var boxTitle string
 		if colorTheme != nil {
-			// Cycle box border color through the color theme, outermost is index 0
-			boxColor = getColorFromTheme(colorTheme, i)
+			// Index the theme from the outermost layer inward (depth-1-i),
+			// so e.g. a gradient's FROM endpoint (theme index 0) lands on
+			// the outermost border, matching how a user reads "FROM:TO".
+			boxColor = getColorFromTheme(colorTheme, depth-1-i)
 		} else if len(boxColors) > 0 {
 			if i < len(boxColors) {
 				boxColor = parseColor(boxColors[i])
@@ -421,11 +750,17 @@ var rootCmd = &cobra.Command{
     Long: `Box is a CLI tool for creating text boxes in the terminal.
 It supports various themes, colors, and nested boxes.
 
+Colors accept a compound spec of colon-separated segments: a foreground
+color (name, 256-color number, or #rrggbb truecolor), a bg=... background
+color, and style attributes (bold, dim, italic, underline, reverse, blink,
+strikethrough).
+
 Examples:
   echo "Hello, world!" | box -t "My Title"
   echo "Hello, world!" | box -t "My Title" -b "red" -c "blue" -n 2
   box "Hello, world!" -t "My Title"
-  box "Line 1" "Line 2" "Line 3"`,
+  box "Line 1" "Line 2" "Line 3"
+  box -b "cyan:bold" -C "#ffaa00:italic:underline" "Styled box"`,
     Args: cobra.ArbitraryArgs,
     RunE: func(cmd *cobra.Command, args []string) error {
         // 1) resolve text input (stdin or arguments)
@@ -440,6 +775,10 @@ Examples:
             return err
         }
 
+        if lscolors {
+            lines = colorizeLSColors(lines, parseLSColors(os.Getenv("LS_COLORS")))
+        }
+
         depth := number
 
         // 2) split comma-lists
@@ -506,12 +845,58 @@ Examples:
 
         // 5) init theme if needed
         var colorTheme *ColorTheme
-        if mode != "" {
+        if gradient != "" {
+            from, to, gerr := parseGradientSpec(gradient)
+            if gerr != nil {
+                return gerr
+            }
+            if depth > 1 {
+                // Nested boxes: interpolate across the border layers.
+                colorTheme = newRGBGradientTheme(from, to, depth)
+            } else if len(lines) > 1 {
+                // A single box: interpolate across the content lines instead.
+                gradTheme := newRGBGradientTheme(from, to, len(lines))
+                for i, line := range lines {
+                    lines[i] = getColorFromTheme(gradTheme, i).Sprint(line)
+                }
+            }
+        } else if mode != "" {
             colorTheme = newColorTheme(mode)
         }
 
         // 6) draw
         theme := getTheme(themeName)
+
+        if join != "" {
+            if join != "horizontal" && join != "vertical" {
+                return fmt.Errorf("--join must be \"horizontal\" or \"vertical\", got %q", join)
+            }
+            groups := splitOnBlankLines(lines)
+            if len(groups) > 0 {
+                boxes := make([]Box, len(groups))
+                for i, g := range groups {
+                    rendered := createNestedBoxes(g, depth, boxColors, titleColors, boxTitles, theme, vpadding, hpadding, contentColor, colorTheme)
+                    boxes[i] = NewBox(rendered)
+                }
+                // A single group (e.g. a trailing blank line with nothing
+                // after it) has nothing to join against; print it as-is
+                // rather than falling back to the raw, unsplit lines, which
+                // would let the blank separator leak into the box.
+                joined := boxes[0]
+                if len(boxes) > 1 {
+                    if join == "horizontal" {
+                        joined = JoinHorizontal(AlignStart, boxes...)
+                    } else {
+                        joined = JoinVertical(AlignStart, boxes...)
+                    }
+                }
+                for _, l := range joined.Lines {
+                    fmt.Println(l)
+                }
+                return nil
+            }
+        }
+
         result := createNestedBoxes(
             lines,
             depth,
@@ -543,6 +928,9 @@ var (
 	themeName   string
 	sep         string
 	mode        string
+	lscolors    bool
+	gradient    string
+	join        string
 )
 
 var docsCmd = &cobra.Command{
@@ -598,14 +986,17 @@ func init() {
 
 	rootCmd.Flags().IntVarP(&number, "number", "n", 1, "Number of nested boxes")
 	rootCmd.Flags().StringVarP(&title, "title", "t", "", "Box titles (comma-separated)")
-	rootCmd.Flags().StringVarP(&boxColor, "box-color", "b", "", "Box border colors (comma-separated)")
-	rootCmd.Flags().StringVarP(&titleColor, "title-color", "c", "", "Title colors (comma-separated)")
-	rootCmd.Flags().StringVarP(&centerColor, "center-color", "C", "", "Center text color")
+	rootCmd.Flags().StringVarP(&boxColor, "box-color", "b", "", "Box border colors (comma-separated, each a color:style spec, e.g. \"red:bold\")")
+	rootCmd.Flags().StringVarP(&titleColor, "title-color", "c", "", "Title colors (comma-separated, each a color:style spec)")
+	rootCmd.Flags().StringVarP(&centerColor, "center-color", "C", "", "Center text color (color:style spec, e.g. \"#ffaa00:italic:underline\")")
 	rootCmd.Flags().IntVarP(&vpadding, "vpadding", "v", 0, "Vertical padding")
 	rootCmd.Flags().IntVarP(&hpadding, "hpadding", "H", 0, "Horizontal padding")
 	rootCmd.Flags().StringVarP(&themeName, "theme", "T", "unicode", "Theme: unicode, ascii, plain")
 	rootCmd.Flags().StringVarP(&sep, "sep", "s", "", "Separator char (unused)")
 	rootCmd.Flags().StringVarP(&mode, "mode", "m", "", "Color mode (random, gradient, rainbow, pride, trans, bi, pan, nb)")
+	rootCmd.Flags().BoolVar(&lscolors, "lscolors", false, "Colorize lines that look like file paths using $LS_COLORS")
+	rootCmd.Flags().StringVar(&gradient, "gradient", "", "Truecolor gradient FROM:TO (e.g. \"#ff0000:#0000ff\"), across nested layers or content lines")
+	rootCmd.Flags().StringVar(&join, "join", "", "Compose blank-line-separated stdin groups into one layout: horizontal or vertical")
 
 	// Register commands
 	rootCmd.AddCommand(completionCmd)
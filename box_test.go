@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+// TestMain forces color output on: fatih/color disables itself when stdout
+// isn't a terminal, which it never is under `go test`, and these tests
+// assert on the actual escape sequences.
+func TestMain(m *testing.M) {
+	color.NoColor = false
+	os.Exit(m.Run())
+}
+
+func TestDisplayWidth(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want int
+	}{
+		{"ascii", "hello", 5},
+		{"cjk", "你好", 4},                     // two double-width characters
+		{"mixed ascii and cjk", "ab你好cd", 8}, // 2 + 4 + 2
+		{"emoji", "😀", 2},                    // wide emoji
+		{"zwj family emoji", "👨‍👩‍👧‍👦", 2},   // one grapheme cluster, counted once
+		{"combining mark", "é", 1},          // "e" + combining acute accent
+		{"empty", "", 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := displayWidth(c.in); got != c.want {
+				t.Errorf("displayWidth(%q) = %d, want %d", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestMaxLineWidth(t *testing.T) {
+	lines := []string{"short", "a longer line", "你好"}
+	if got, want := maxLineWidth(lines), 13; got != want {
+		t.Errorf("maxLineWidth(%v) = %d, want %d", lines, got, want)
+	}
+}
+
+// wantSGRPrefix asserts that sprinted starts with the given SGR open
+// sequence around "x". The exact reset/unset suffix fatih/color appends is
+// an internal implementation detail, so only the opening escape is checked.
+func wantSGRPrefix(t *testing.T, sprinted, openSeq string) {
+	t.Helper()
+	want := openSeq + "x"
+	if !strings.HasPrefix(sprinted, want) {
+		t.Errorf("sprinted = %q, want prefix %q", sprinted, want)
+	}
+}
+
+func TestParseColorNamed(t *testing.T) {
+	wantSGRPrefix(t, parseColor("red").Sprint("x"), "\x1b[31m")
+}
+
+func TestParseColorHexTruecolor(t *testing.T) {
+	wantSGRPrefix(t, parseColor("#ff8800").Sprint("x"), "\x1b[38;2;255;136;0m")
+}
+
+func TestParseColor256(t *testing.T) {
+	wantSGRPrefix(t, parseColor("112").Sprint("x"), "\x1b[38;5;112m")
+}
+
+func TestParseColorCompoundSpec(t *testing.T) {
+	wantSGRPrefix(t, parseColor("red:bold:underline").Sprint("x"), "\x1b[31;1;4m")
+}
+
+func TestParseColorBackground(t *testing.T) {
+	wantSGRPrefix(t, parseColor("#ff8800:italic:bg=blue").Sprint("x"), "\x1b[38;2;255;136;0;3;44m")
+}
+
+func TestParseColorUnknownFallsBackToReset(t *testing.T) {
+	wantSGRPrefix(t, parseColor("not-a-color").Sprint("x"), "\x1b[0m")
+}
+
+func TestParseLSColors(t *testing.T) {
+	m := parseLSColors("di=01;34:ln=01;36:*.tar=01;31:")
+	want := map[string]string{"di": "01;34", "ln": "01;36", "*.tar": "01;31"}
+	if len(m) != len(want) {
+		t.Fatalf("parseLSColors() = %v, want %v", m, want)
+	}
+	for k, v := range want {
+		if m[k] != v {
+			t.Errorf("parseLSColors()[%q] = %q, want %q", k, m[k], v)
+		}
+	}
+}
+
+func TestParseLSColorsEmpty(t *testing.T) {
+	m := parseLSColors("")
+	if len(m) != 0 {
+		t.Errorf("parseLSColors(\"\") = %v, want empty map", m)
+	}
+}
+
+func TestLSColorStyleForPathProseIsUncolored(t *testing.T) {
+	lsColors := parseLSColors("mi=01;31")
+	if _, ok := lsColorStyleForPath("Hello, world! This is definitely not a path.", lsColors); ok {
+		t.Error("lsColorStyleForPath matched prose that is not a real path, want no match")
+	}
+}
+
+func TestLSColorStyleForPathBlankLineIsUncolored(t *testing.T) {
+	lsColors := parseLSColors("fi=00")
+	if _, ok := lsColorStyleForPath("", lsColors); ok {
+		t.Error("lsColorStyleForPath matched an empty line, want no match")
+	}
+}
+
+func TestLSColorStyleForPathDirectory(t *testing.T) {
+	dir := t.TempDir()
+	lsColors := parseLSColors("di=01;34")
+	style, ok := lsColorStyleForPath(dir, lsColors)
+	if !ok || style != "01;34" {
+		t.Errorf("lsColorStyleForPath(%q) = (%q, %v), want (\"01;34\", true)", dir, style, ok)
+	}
+}
+
+func TestLSColorStyleForPathExtensionGlob(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "archive.tar")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lsColors := parseLSColors("fi=00:*.tar=01;31")
+	style, ok := lsColorStyleForPath(path, lsColors)
+	if !ok || style != "01;31" {
+		t.Errorf("lsColorStyleForPath(%q) = (%q, %v), want (\"01;31\", true)", path, style, ok)
+	}
+}
+
+func TestColorizeLSColorsPreservesBlankLines(t *testing.T) {
+	lsColors := parseLSColors("mi=01;31")
+	lines := []string{"regular.txt", "", "b"}
+	out := colorizeLSColors(lines, lsColors)
+	if out[1] != "" {
+		t.Errorf("colorizeLSColors kept blank separator as %q, want empty string", out[1])
+	}
+}
+
+func TestParseGradientSpec(t *testing.T) {
+	from, to, err := parseGradientSpec("#ff0000:#0000ff")
+	if err != nil {
+		t.Fatalf("parseGradientSpec returned error: %v", err)
+	}
+	if from != ([3]int{255, 0, 0}) || to != ([3]int{0, 0, 255}) {
+		t.Errorf("parseGradientSpec(\"#ff0000:#0000ff\") = (%v, %v), want ([255 0 0], [0 0 255])", from, to)
+	}
+}
+
+func TestParseGradientSpecInvalid(t *testing.T) {
+	if _, _, err := parseGradientSpec("red:blue"); err == nil {
+		t.Error("parseGradientSpec(\"red:blue\") returned no error, want an error for non-hex endpoints")
+	}
+	if _, _, err := parseGradientSpec("#ff0000"); err == nil {
+		t.Error("parseGradientSpec(\"#ff0000\") returned no error, want an error for a missing TO endpoint")
+	}
+}
+
+func TestSRGBLinearRoundTrip(t *testing.T) {
+	for c := 0; c <= 255; c += 17 {
+		got := linearToSRGB(sRGBToLinear(c))
+		if diff := got - c; diff < -1 || diff > 1 {
+			t.Errorf("linearToSRGB(sRGBToLinear(%d)) = %d, want within 1 of %d", c, got, c)
+		}
+	}
+}
+
+func TestLerpRGBLinearEndpoints(t *testing.T) {
+	from := [3]int{255, 0, 0}
+	to := [3]int{0, 0, 255}
+	if got := lerpRGBLinear(from, to, 0); got != from {
+		t.Errorf("lerpRGBLinear(from, to, 0) = %v, want %v", got, from)
+	}
+	if got := lerpRGBLinear(from, to, 1); got != to {
+		t.Errorf("lerpRGBLinear(from, to, 1) = %v, want %v", got, to)
+	}
+}
+
+func TestLerpRGBLinearMidpointIsBetweenEndpoints(t *testing.T) {
+	from := [3]int{255, 0, 0}
+	to := [3]int{0, 0, 255}
+	mid := lerpRGBLinear(from, to, 0.5)
+	for i := range mid {
+		lo, hi := from[i], to[i]
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		if mid[i] < lo || mid[i] > hi {
+			t.Errorf("lerpRGBLinear midpoint channel %d = %d, want within [%d, %d]", i, mid[i], lo, hi)
+		}
+	}
+}
+
+func TestGetColorFromThemeGradientDirection(t *testing.T) {
+	from := [3]int{255, 0, 0}
+	to := [3]int{0, 0, 255}
+	theme := newRGBGradientTheme(from, to, 3)
+	wantSGRPrefix(t, getColorFromTheme(theme, 0).Sprint("x"), "\x1b[38;2;255;0;0m")
+	wantSGRPrefix(t, getColorFromTheme(theme, 2).Sprint("x"), "\x1b[38;2;0;0;255m")
+}
+
+func TestNestedGradientPutsFromOnOutermostLayer(t *testing.T) {
+	depth := 3
+	theme := newRGBGradientTheme([3]int{255, 0, 0}, [3]int{0, 0, 255}, depth)
+	lines := createNestedBoxes([]string{"hi"}, depth, nil, nil, nil, getTheme("unicode"), 0, 0, nil, theme)
+	top := lines[0]
+	if !strings.Contains(top, "\x1b[38;2;255;0;0m") {
+		t.Errorf("outermost top border %q does not contain the FROM endpoint color", top)
+	}
+}
+
+func TestSplitOnBlankLines(t *testing.T) {
+	groups := splitOnBlankLines([]string{"a", "b", "", "c"})
+	if len(groups) != 2 || len(groups[0]) != 2 || len(groups[1]) != 1 {
+		t.Errorf("splitOnBlankLines(...) = %v, want [[a b] [c]]", groups)
+	}
+}
+
+func TestSplitOnBlankLinesTrailingBlank(t *testing.T) {
+	groups := splitOnBlankLines([]string{"abc", ""})
+	if len(groups) != 1 || len(groups[0]) != 1 || groups[0][0] != "abc" {
+		t.Errorf("splitOnBlankLines([\"abc\", \"\"]) = %v, want [[abc]]", groups)
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	r, g, b, ok := parseHexColor("#112233")
+	if !ok || r != 0x11 || g != 0x22 || b != 0x33 {
+		t.Errorf("parseHexColor(\"#112233\") = (%d, %d, %d, %v), want (17, 34, 51, true)", r, g, b, ok)
+	}
+	if _, _, _, ok := parseHexColor("not-hex"); ok {
+		t.Error("parseHexColor(\"not-hex\") reported ok, want false")
+	}
+}
+
+func TestMathSanity(t *testing.T) {
+	// sRGBToLinear should be monotonic: pure documentation of intent, not a
+	// precision guarantee.
+	if sRGBToLinear(0) >= sRGBToLinear(255) {
+		t.Error("sRGBToLinear should increase with channel value")
+	}
+	if math.Abs(sRGBToLinear(255)-1.0) > 0.001 {
+		t.Errorf("sRGBToLinear(255) = %v, want ~1.0", sRGBToLinear(255))
+	}
+}
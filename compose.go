@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: MIT
+//
+// compose.go - Compose multiple rendered boxes into dashboard-style layouts.
+
+package main
+
+import (
+	"strings"
+)
+
+// Align specifies how a shorter or narrower box is padded against its
+// taller/wider neighbors when composing a layout.
+type Align int
+
+const (
+	AlignStart Align = iota
+	AlignCenter
+	AlignEnd
+)
+
+// Box is a rendered set of lines (e.g. the output of createBox or
+// createNestedBoxes) that can be composed with other boxes into larger,
+// dashboard-style layouts.
+type Box struct {
+	Lines []string
+}
+
+// NewBox wraps already-rendered lines, which may contain ANSI escapes, as a
+// Box.
+func NewBox(lines []string) Box {
+	return Box{Lines: lines}
+}
+
+// Width returns the display width of the widest line in the box, using
+// displayWidth so CJK, emoji, and combining marks are counted by their
+// terminal column width rather than by rune count.
+func (b Box) Width() int {
+	width := 0
+	for _, line := range b.Lines {
+		if w := displayWidth(stripAnsi(line)); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// Height returns the number of lines in the box.
+func (b Box) Height() int {
+	return len(b.Lines)
+}
+
+// Place pads b to exactly width x height, inserting space-filled lines and
+// left/right/top/bottom padding according to hAlign/vAlign.
+func (b Box) Place(width, height int, hAlign, vAlign Align) Box {
+	lines := make([]string, len(b.Lines))
+	copy(lines, b.Lines)
+
+	for i, line := range lines {
+		pad := width - displayWidth(stripAnsi(line))
+		if pad <= 0 {
+			continue
+		}
+		left, right := padAmounts(pad, hAlign)
+		lines[i] = strings.Repeat(" ", left) + line + strings.Repeat(" ", right)
+	}
+
+	if extra := height - len(lines); extra > 0 {
+		blank := strings.Repeat(" ", width)
+		top, bottom := padAmounts(extra, vAlign)
+		padded := append(repeatLine(blank, top), lines...)
+		lines = append(padded, repeatLine(blank, bottom)...)
+	}
+	return Box{Lines: lines}
+}
+
+// padAmounts splits n units of padding into (before, after) according to
+// align.
+func padAmounts(n int, align Align) (before, after int) {
+	switch align {
+	case AlignCenter:
+		before = n / 2
+		after = n - before
+	case AlignEnd:
+		before = n
+	default: // AlignStart
+		after = n
+	}
+	return before, after
+}
+
+func repeatLine(line string, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = line
+	}
+	return out
+}
+
+// JoinHorizontal places boxes side by side, separated by a single-space
+// column, aligning each to the tallest box's height per vAlign.
+func JoinHorizontal(vAlign Align, boxes ...Box) Box {
+	if len(boxes) == 0 {
+		return Box{}
+	}
+	height := 0
+	for _, b := range boxes {
+		if h := b.Height(); h > height {
+			height = h
+		}
+	}
+	placed := make([]Box, len(boxes))
+	for i, b := range boxes {
+		placed[i] = b.Place(b.Width(), height, AlignStart, vAlign)
+	}
+	lines := make([]string, height)
+	for row := 0; row < height; row++ {
+		var sb strings.Builder
+		for i, b := range placed {
+			if i > 0 {
+				sb.WriteString(" ")
+			}
+			sb.WriteString(b.Lines[row])
+		}
+		lines[row] = sb.String()
+	}
+	return Box{Lines: lines}
+}
+
+// JoinVertical stacks boxes top to bottom, aligning each to the widest box's
+// width per hAlign.
+func JoinVertical(hAlign Align, boxes ...Box) Box {
+	if len(boxes) == 0 {
+		return Box{}
+	}
+	width := 0
+	for _, b := range boxes {
+		if w := b.Width(); w > width {
+			width = w
+		}
+	}
+	var lines []string
+	for _, b := range boxes {
+		lines = append(lines, b.Place(width, b.Height(), hAlign, AlignStart).Lines...)
+	}
+	return Box{Lines: lines}
+}
+
+// splitOnBlankLines splits lines into groups separated by blank lines, for
+// the --join stdin format where each group renders as an independent box.
+func splitOnBlankLines(lines []string) [][]string {
+	var groups [][]string
+	var current []string
+	for _, line := range lines {
+		if line == "" {
+			if len(current) > 0 {
+				groups = append(groups, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+	return groups
+}
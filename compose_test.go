@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+
+package main
+
+import "testing"
+
+func TestBoxWidthHeight(t *testing.T) {
+	b := NewBox([]string{"abc", "你好"}) // 3 cols, then 4 cols
+	if got, want := b.Width(), 4; got != want {
+		t.Errorf("Box.Width() = %d, want %d", got, want)
+	}
+	if got, want := b.Height(), 2; got != want {
+		t.Errorf("Box.Height() = %d, want %d", got, want)
+	}
+}
+
+func TestBoxPlacePadsToSize(t *testing.T) {
+	b := NewBox([]string{"ab"})
+	placed := b.Place(4, 3, AlignStart, AlignStart)
+	want := []string{"ab  ", "    ", "    "}
+	if len(placed.Lines) != len(want) {
+		t.Fatalf("Place() returned %d lines, want %d", len(placed.Lines), len(want))
+	}
+	for i, line := range placed.Lines {
+		if line != want[i] {
+			t.Errorf("Place() line %d = %q, want %q", i, line, want[i])
+		}
+	}
+}
+
+func TestBoxPlaceCenterAlign(t *testing.T) {
+	b := NewBox([]string{"ab"})
+	placed := b.Place(6, 1, AlignCenter, AlignStart)
+	if got, want := placed.Lines[0], "  ab  "; got != want {
+		t.Errorf("Place() centered = %q, want %q", got, want)
+	}
+}
+
+func TestJoinHorizontalAlignsHeights(t *testing.T) {
+	a := NewBox([]string{"aa", "aa"})
+	b := NewBox([]string{"b"})
+	joined := JoinHorizontal(AlignStart, a, b)
+	want := []string{"aa b", "aa  "}
+	if len(joined.Lines) != len(want) {
+		t.Fatalf("JoinHorizontal() returned %d lines, want %d", len(joined.Lines), len(want))
+	}
+	for i, line := range joined.Lines {
+		if line != want[i] {
+			t.Errorf("JoinHorizontal() line %d = %q, want %q", i, line, want[i])
+		}
+	}
+}
+
+func TestJoinVerticalAlignsWidths(t *testing.T) {
+	a := NewBox([]string{"a"})
+	b := NewBox([]string{"bb"})
+	joined := JoinVertical(AlignStart, a, b)
+	want := []string{"a ", "bb"}
+	if len(joined.Lines) != len(want) {
+		t.Fatalf("JoinVertical() returned %d lines, want %d", len(joined.Lines), len(want))
+	}
+	for i, line := range joined.Lines {
+		if line != want[i] {
+			t.Errorf("JoinVertical() line %d = %q, want %q", i, line, want[i])
+		}
+	}
+}
+
+func TestJoinHorizontalEmpty(t *testing.T) {
+	if got := JoinHorizontal(AlignStart); len(got.Lines) != 0 {
+		t.Errorf("JoinHorizontal() with no boxes = %v, want empty", got.Lines)
+	}
+}
@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: MIT
+//
+// tui.go - Interactive terminal UI for box, with live preview and resizing.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tuiModes  = []string{"", "random", "gradient", "rainbow", "pride", "trans", "bi", "pan", "nb"}
+	tuiThemes = []string{"unicode", "ascii", "plain"}
+)
+
+// tuiState holds the parameters a user can adjust live in the TUI, mirroring
+// the CLI flags that configure createNestedBoxes.
+type tuiState struct {
+	lines      []string
+	modeIndex  int
+	themeIndex int
+	depth      int
+	vpadding   int
+	hpadding   int
+	// command, when true, routes q/+/-/n/N to the theme/mode/padding/depth
+	// commands below instead of inserting them as text. Tab toggles it.
+	// It starts false so typing into a fresh buffer never loses characters.
+	command bool
+}
+
+// render produces the box lines for the current state, reusing the same
+// createNestedBoxes/createBox pipeline as the CLI so the preview and the
+// final stdout output never diverge.
+func (s *tuiState) render() []string {
+	theme := getTheme(tuiThemes[s.themeIndex])
+	var colorTheme *ColorTheme
+	if tuiModes[s.modeIndex] != "" {
+		colorTheme = newColorTheme(tuiModes[s.modeIndex])
+	}
+	lines := s.lines
+	if len(lines) == 0 {
+		lines = []string{""}
+	}
+	return createNestedBoxes(lines, s.depth, nil, nil, nil, theme, s.vpadding, s.hpadding, nil, colorTheme)
+}
+
+// drawText writes s onto the screen starting at (x, y) using style,
+// advancing by each rune's display width (via go-runewidth) rather than its
+// byte offset, so multi-byte glyphs like the unicode theme's box-drawing
+// characters and the status bar's arrows land in the right column.
+func drawText(screen tcell.Screen, x, y int, s string, style tcell.Style) {
+	col := x
+	for _, r := range s {
+		screen.SetContent(col, y, r, nil, style)
+		col += runewidth.RuneWidth(r)
+	}
+}
+
+// orNone returns "none" for an empty color mode name, for display purposes.
+func orNone(mode string) string {
+	if mode == "" {
+		return "none"
+	}
+	return mode
+}
+
+// runTUI opens a full-screen terminal UI for editing text in a left pane
+// while previewing the rendered box in a right pane. It restores the
+// terminal on exit or panic, and writes the final rendered box to stdout
+// so the result remains pipeable.
+func runTUI(initialLines []string) error {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return fmt.Errorf("failed to initialize terminal: %v", err)
+	}
+	if err := screen.Init(); err != nil {
+		return fmt.Errorf("failed to initialize terminal: %v", err)
+	}
+	defer screen.Fini()
+	defer func() {
+		if r := recover(); r != nil {
+			screen.Fini()
+			panic(r)
+		}
+	}()
+
+	state := &tuiState{lines: append([]string(nil), initialLines...), depth: 1}
+	if len(state.lines) == 0 {
+		state.lines = []string{""}
+	}
+	cursorLine := len(state.lines) - 1
+
+	draw := func() {
+		screen.Clear()
+		w, h := screen.Size()
+		half := w / 2
+
+		for y, line := range state.lines {
+			if y >= h-1 {
+				break
+			}
+			drawText(screen, 0, y, line, tcell.StyleDefault)
+		}
+		for y := 0; y < h-1; y++ {
+			screen.SetContent(half, y, '│', nil, tcell.StyleDefault)
+		}
+		for y, line := range state.render() {
+			if y >= h-1 {
+				break
+			}
+			drawText(screen, half+2, y, stripAnsi(line), tcell.StyleDefault)
+		}
+
+		commandHint := "Tab=commands (type freely)"
+		if state.command {
+			commandHint = "Tab=type  ↑↓=theme ←→=mode +/-=padding n/N=depth q=exit"
+		}
+		status := fmt.Sprintf(
+			"theme:%s mode:%s depth:%d pad:%d/%d  %s  Ctrl+D=exit",
+			tuiThemes[state.themeIndex], orNone(tuiModes[state.modeIndex]), state.depth, state.vpadding, state.hpadding, commandHint,
+		)
+		drawText(screen, 0, h-1, status, tcell.StyleDefault.Reverse(true))
+
+		screen.ShowCursor(displayWidth(state.lines[cursorLine]), cursorLine)
+		screen.Show()
+	}
+
+	draw()
+	for {
+		ev := screen.PollEvent()
+		switch ev := ev.(type) {
+		case *tcell.EventResize:
+			screen.Sync()
+			draw()
+		case *tcell.EventKey:
+			switch {
+			case ev.Key() == tcell.KeyCtrlD:
+				goto done
+			case ev.Key() == tcell.KeyTab:
+				state.command = !state.command
+			case ev.Key() == tcell.KeyEnter && len(state.lines) == 1:
+				// A single-line buffer treats Enter as "done"; multi-line
+				// buffers insert a newline instead, below.
+				goto done
+			case state.command && ev.Rune() == 'q':
+				goto done
+			case state.command && ev.Key() == tcell.KeyUp:
+				state.themeIndex = (state.themeIndex + len(tuiThemes) - 1) % len(tuiThemes)
+			case state.command && ev.Key() == tcell.KeyDown:
+				state.themeIndex = (state.themeIndex + 1) % len(tuiThemes)
+			case state.command && ev.Key() == tcell.KeyLeft:
+				state.modeIndex = (state.modeIndex + len(tuiModes) - 1) % len(tuiModes)
+			case state.command && ev.Key() == tcell.KeyRight:
+				state.modeIndex = (state.modeIndex + 1) % len(tuiModes)
+			case state.command && ev.Rune() == '+':
+				state.hpadding++
+				state.vpadding++
+			case state.command && ev.Rune() == '-':
+				if state.hpadding > 0 {
+					state.hpadding--
+				}
+				if state.vpadding > 0 {
+					state.vpadding--
+				}
+			case state.command && ev.Rune() == 'n':
+				state.depth++
+			case state.command && ev.Rune() == 'N':
+				if state.depth > 1 {
+					state.depth--
+				}
+			case ev.Key() == tcell.KeyBackspace || ev.Key() == tcell.KeyBackspace2:
+				line := state.lines[cursorLine]
+				if len(line) > 0 {
+					runes := []rune(line)
+					state.lines[cursorLine] = string(runes[:len(runes)-1])
+				}
+			case ev.Key() == tcell.KeyEnter:
+				state.lines = append(state.lines, "")
+				cursorLine++
+			case ev.Rune() != 0:
+				state.lines[cursorLine] += string(ev.Rune())
+			}
+			draw()
+		}
+	}
+done:
+	for _, l := range state.render() {
+		fmt.Println(l)
+	}
+	return nil
+}
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [text...]",
+	Short: "Open an interactive TUI to edit and preview a box",
+	Long: `Opens a full-screen terminal UI: edit text in the left pane while the
+rendered box updates live in the right pane. Typing inserts text; press Tab
+to switch to command mode, where up/down cycle themes, left/right cycle
+color modes, +/- adjust padding, n/N adjust nesting depth, and q exits.
+Press Tab again to go back to typing. Enter (on a single-line buffer) or
+Ctrl+D also exit at any time; the final rendered box is written to stdout
+so it remains pipeable.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lines, err := resolveTextInput(args)
+		if err != nil && !strings.Contains(err.Error(), "no input provided") {
+			return err
+		}
+		return runTUI(lines)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}